@@ -0,0 +1,315 @@
+package gosvg
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Defs represents a container for reusable definitions (the defs element).
+type Defs struct {
+	BaseAttrs
+	container
+}
+
+// Defs generates a new defs container within the given SVG.
+func (c *SVG) Defs() *Defs {
+	d := &Defs{container: container{name: "defs"}}
+	c.contents = append(c.contents, d)
+
+	return d
+}
+
+func (d *Defs) render(w io.Writer) error {
+	return d.container.render(w, d.BaseAttrs.attrStrings())
+}
+
+// Stop represents a gradient color stop (the stop element).
+type Stop struct {
+	Offset  float64
+	Color   string
+	Opacity float64
+}
+
+func (s Stop) render(w io.Writer) error {
+	offset := floatAttr("offset", s.Offset)
+	color := stringAttr("stop-color", s.Color)
+	opacity := floatAttr("stop-opacity", s.Opacity)
+
+	out := fmt.Sprintf("<stop %s %s %s/>", offset, color, opacity)
+	_, err := w.Write([]byte(out))
+
+	return err
+}
+
+// gradientStops holds the color stops shared by LinearGradient and RadialGradient.
+type gradientStops struct {
+	stops []Stop
+}
+
+func (g *gradientStops) addStop(offset float64, color string, opacity float64) {
+	g.stops = append(g.stops, Stop{Offset: offset, Color: color, Opacity: opacity})
+}
+
+func (g *gradientStops) renderStops(w io.Writer) error {
+	for _, s := range g.stops {
+		if err := s.render(w); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// LinearGradient represents a linear gradient paint server (the linearGradient element).
+type LinearGradient struct {
+	BaseAttrs
+	gradientStops
+	ID            string
+	X1            float64
+	Y1            float64
+	X2            float64
+	Y2            float64
+	SpreadMethod  string
+	GradientUnits string
+}
+
+// LinearGradient generates a new linear gradient within the given defs.
+func (d *Defs) LinearGradient(id string) *LinearGradient {
+	g := &LinearGradient{ID: id}
+	d.contents = append(d.contents, g)
+
+	return g
+}
+
+// Stop appends a color stop to the gradient.
+func (g *LinearGradient) Stop(offset float64, color string, opacity float64) *LinearGradient {
+	g.addStop(offset, color, opacity)
+
+	return g
+}
+
+func (g *LinearGradient) attrStrings() []string {
+	attrs := g.BaseAttrs.attrStrings()
+
+	attrs = append(attrs,
+		stringAttr("id", g.ID),
+		floatAttr("x1", g.X1),
+		floatAttr("y1", g.Y1),
+		floatAttr("x2", g.X2),
+		floatAttr("y2", g.Y2))
+
+	if g.SpreadMethod != "" {
+		attrs = append(attrs, stringAttr("spreadMethod", g.SpreadMethod))
+	}
+
+	if g.GradientUnits != "" {
+		attrs = append(attrs, stringAttr("gradientUnits", g.GradientUnits))
+	}
+
+	return attrs
+}
+
+func (g *LinearGradient) render(w io.Writer) error {
+	attrString := strings.Join(g.attrStrings(), " ")
+
+	opening := fmt.Sprintf("<linearGradient %s>", attrString)
+	if _, err := w.Write([]byte(opening)); err != nil {
+		return err
+	}
+
+	if err := g.renderStops(w); err != nil {
+		return err
+	}
+
+	_, err := w.Write([]byte("</linearGradient>"))
+
+	return err
+}
+
+// RadialGradient represents a radial gradient paint server (the radialGradient element).
+type RadialGradient struct {
+	BaseAttrs
+	gradientStops
+	ID            string
+	Cx            float64
+	Cy            float64
+	R             float64
+	Fx            *float64
+	Fy            *float64
+	SpreadMethod  string
+	GradientUnits string
+}
+
+// RadialGradient generates a new radial gradient within the given defs.
+func (d *Defs) RadialGradient(id string) *RadialGradient {
+	g := &RadialGradient{ID: id}
+	d.contents = append(d.contents, g)
+
+	return g
+}
+
+// Stop appends a color stop to the gradient.
+func (g *RadialGradient) Stop(offset float64, color string, opacity float64) *RadialGradient {
+	g.addStop(offset, color, opacity)
+
+	return g
+}
+
+func (g *RadialGradient) attrStrings() []string {
+	attrs := g.BaseAttrs.attrStrings()
+
+	attrs = append(attrs,
+		stringAttr("id", g.ID),
+		floatAttr("cx", g.Cx),
+		floatAttr("cy", g.Cy),
+		floatAttr("r", g.R))
+
+	if g.Fx != nil {
+		attrs = append(attrs, floatAttr("fx", *g.Fx))
+	}
+
+	if g.Fy != nil {
+		attrs = append(attrs, floatAttr("fy", *g.Fy))
+	}
+
+	if g.SpreadMethod != "" {
+		attrs = append(attrs, stringAttr("spreadMethod", g.SpreadMethod))
+	}
+
+	if g.GradientUnits != "" {
+		attrs = append(attrs, stringAttr("gradientUnits", g.GradientUnits))
+	}
+
+	return attrs
+}
+
+func (g *RadialGradient) render(w io.Writer) error {
+	attrString := strings.Join(g.attrStrings(), " ")
+
+	opening := fmt.Sprintf("<radialGradient %s>", attrString)
+	if _, err := w.Write([]byte(opening)); err != nil {
+		return err
+	}
+
+	if err := g.renderStops(w); err != nil {
+		return err
+	}
+
+	_, err := w.Write([]byte("</radialGradient>"))
+
+	return err
+}
+
+// Pattern represents a tiled paint server (the pattern element).
+type Pattern struct {
+	BaseAttrs
+	container
+	ID           string
+	X            float64
+	Y            float64
+	Width        float64
+	Height       float64
+	PatternUnits string
+}
+
+// Pattern generates a new pattern within the given defs.
+func (d *Defs) Pattern(id string, x, y, width, height float64) *Pattern {
+	p := &Pattern{
+		ID:        id,
+		X:         x,
+		Y:         y,
+		Width:     width,
+		Height:    height,
+		container: container{name: "pattern"},
+	}
+	d.contents = append(d.contents, p)
+
+	return p
+}
+
+func (p *Pattern) attrStrings() []string {
+	attrs := p.BaseAttrs.attrStrings()
+
+	attrs = append(attrs,
+		stringAttr("id", p.ID),
+		floatAttr("x", p.X),
+		floatAttr("y", p.Y),
+		floatAttr("width", p.Width),
+		floatAttr("height", p.Height))
+
+	if p.PatternUnits != "" {
+		attrs = append(attrs, stringAttr("patternUnits", p.PatternUnits))
+	}
+
+	return attrs
+}
+
+func (p *Pattern) render(w io.Writer) error {
+	return p.container.render(w, p.attrStrings())
+}
+
+// Symbol represents a reusable graphics template (the symbol element).
+type Symbol struct {
+	BaseAttrs
+	container
+	ID      string
+	ViewBox ViewBox
+}
+
+// Symbol generates a new symbol within the given defs.
+func (d *Defs) Symbol(id string) *Symbol {
+	s := &Symbol{ID: id, container: container{name: "symbol"}}
+	d.contents = append(d.contents, s)
+
+	return s
+}
+
+func (s *Symbol) attrStrings() []string {
+	attrs := s.BaseAttrs.attrStrings()
+	attrs = append(attrs, stringAttr("id", s.ID))
+
+	if viewBox := s.ViewBox.attrString(); viewBox != "" {
+		attrs = append(attrs, viewBox)
+	}
+
+	return attrs
+}
+
+func (s *Symbol) render(w io.Writer) error {
+	return s.container.render(w, s.attrStrings())
+}
+
+// Use represents a reference to a previously defined element (the use element).
+type Use struct {
+	ShapeAttrs
+	Href string
+	X    float64
+	Y    float64
+}
+
+// Use generates a new use element in the given container, referencing href
+// (typically "#id" of a Symbol, Pattern, or gradient defined in a Defs).
+func (c *container) Use(href string, x, y float64) *Use {
+	u := &Use{Href: href, X: x, Y: y}
+	c.contents = append(c.contents, u)
+
+	return u
+}
+
+func (u *Use) attrStrings() []string {
+	href := stringAttr("xlink:href", u.Href)
+	x := floatAttr("x", u.X)
+	y := floatAttr("y", u.Y)
+
+	return append(u.ShapeAttrs.attrStrings(), href, x, y)
+}
+
+func (u *Use) render(w io.Writer) error {
+	attrString := strings.Join(u.attrStrings(), " ")
+
+	out := fmt.Sprintf("<use %s/>", attrString)
+	_, err := w.Write([]byte(out))
+
+	return err
+}
@@ -0,0 +1,371 @@
+package gosvg
+
+import "math"
+
+// DefaultFlatness is a reasonable default tolerance, in user units, for
+// Path.Flatten and Path.FlattenSubpaths.
+const DefaultFlatness = 0.05
+
+// Flatten walks the path's commands, tracking the current point, and
+// converts every Bezier and elliptical arc segment into a sequence of line
+// segments whose maximum deviation from the true curve is at most
+// tolerance. The returned points span every subpath in order; use
+// FlattenSubpaths to keep subpaths separate.
+func (p *Path) Flatten(tolerance float64) []Point {
+	var out []Point
+
+	for _, sub := range p.FlattenSubpaths(tolerance) {
+		out = append(out, sub...)
+	}
+
+	return out
+}
+
+// FlattenSubpaths is like Flatten, but splits the result at each M/Z
+// subpath boundary.
+func (p *Path) FlattenSubpaths(tolerance float64) [][]Point {
+	var subpaths [][]Point
+	var cur []Point
+
+	var (
+		pos       Point
+		subStart  Point
+		prevCtrl  Point
+		havePrevC bool
+		havePrevQ bool
+	)
+
+	emit := func(pt Point) {
+		cur = append(cur, pt)
+		pos = pt
+	}
+
+	for _, c := range p.d {
+		// The builder methods (La, Ha, Ca, Aa, ...) don't populate each
+		// cmdBody's isAbs field, so absoluteness is derived from the command
+		// letter itself: uppercase is absolute, lowercase is relative.
+		isAbs := isAbsCmdName(c.name)
+
+		switch body := c.body.(type) {
+		case mCmd:
+			if len(cur) > 0 {
+				subpaths = append(subpaths, cur)
+			}
+			cur = nil
+
+			for i, pt := range body.pts {
+				abs := pt
+				if !isAbs {
+					abs = Point{X: pos.X + pt.X, Y: pos.Y + pt.Y}
+				}
+				if i == 0 {
+					subStart = abs
+				}
+				emit(abs)
+			}
+			havePrevC, havePrevQ = false, false
+		case zCmd:
+			emit(subStart)
+			havePrevC, havePrevQ = false, false
+		case elCmd:
+			for _, pt := range body.pts {
+				abs := pt
+				if !isAbs {
+					abs = Point{X: pos.X + pt.X, Y: pos.Y + pt.Y}
+				}
+				emit(abs)
+			}
+			havePrevC, havePrevQ = false, false
+		case hCmd:
+			for _, x := range body.xs {
+				nx := x
+				if !isAbs {
+					nx = pos.X + x
+				}
+				emit(Point{X: nx, Y: pos.Y})
+			}
+			havePrevC, havePrevQ = false, false
+		case vCmd:
+			for _, y := range body.ys {
+				ny := y
+				if !isAbs {
+					ny = pos.Y + y
+				}
+				emit(Point{X: pos.X, Y: ny})
+			}
+			havePrevC, havePrevQ = false, false
+		case cCmd:
+			for _, cv := range body.cvs {
+				p0 := pos
+
+				var ctrl1, ctrl2, end Point
+				if isAbs {
+					ctrl1 = Point{X: cv.X1, Y: cv.Y1}
+					ctrl2 = Point{X: cv.X2, Y: cv.Y2}
+					end = Point{X: cv.X, Y: cv.Y}
+				} else {
+					ctrl1 = Point{X: pos.X + cv.X1, Y: pos.Y + cv.Y1}
+					ctrl2 = Point{X: pos.X + cv.X2, Y: pos.Y + cv.Y2}
+					end = Point{X: pos.X + cv.X, Y: pos.Y + cv.Y}
+				}
+
+				cur = append(cur, flattenCubic(p0, ctrl1, ctrl2, end, tolerance)...)
+				pos = end
+				prevCtrl = ctrl2
+				havePrevC = true
+			}
+			havePrevQ = false
+		case sCmd:
+			for _, cv := range body.cvs {
+				p0 := pos
+
+				ctrl1 := pos
+				if havePrevC {
+					ctrl1 = Point{X: 2*pos.X - prevCtrl.X, Y: 2*pos.Y - prevCtrl.Y}
+				}
+
+				var ctrl2, end Point
+				if isAbs {
+					ctrl2 = Point{X: cv.X2, Y: cv.Y2}
+					end = Point{X: cv.X, Y: cv.Y}
+				} else {
+					ctrl2 = Point{X: pos.X + cv.X2, Y: pos.Y + cv.Y2}
+					end = Point{X: pos.X + cv.X, Y: pos.Y + cv.Y}
+				}
+
+				cur = append(cur, flattenCubic(p0, ctrl1, ctrl2, end, tolerance)...)
+				pos = end
+				prevCtrl = ctrl2
+				havePrevC = true
+			}
+			havePrevQ = false
+		case qCmd:
+			for _, cv := range body.cvs {
+				p0 := pos
+
+				var ctrl, end Point
+				if isAbs {
+					ctrl = Point{X: cv.X1, Y: cv.Y1}
+					end = Point{X: cv.X, Y: cv.Y}
+				} else {
+					ctrl = Point{X: pos.X + cv.X1, Y: pos.Y + cv.Y1}
+					end = Point{X: pos.X + cv.X, Y: pos.Y + cv.Y}
+				}
+
+				cur = append(cur, flattenQuadratic(p0, ctrl, end, tolerance)...)
+				pos = end
+				prevCtrl = ctrl
+				havePrevQ = true
+			}
+			havePrevC = false
+		case tCmd:
+			for _, pt := range body.pts {
+				p0 := pos
+
+				ctrl := pos
+				if havePrevQ {
+					ctrl = Point{X: 2*pos.X - prevCtrl.X, Y: 2*pos.Y - prevCtrl.Y}
+				}
+
+				end := pt
+				if !isAbs {
+					end = Point{X: pos.X + pt.X, Y: pos.Y + pt.Y}
+				}
+
+				cur = append(cur, flattenQuadratic(p0, ctrl, end, tolerance)...)
+				pos = end
+				prevCtrl = ctrl
+				havePrevQ = true
+			}
+			havePrevC = false
+		case aCmd:
+			for _, arc := range body.arcs {
+				p0 := pos
+
+				end := Point{X: arc.X, Y: arc.Y}
+				if !isAbs {
+					end = Point{X: pos.X + arc.X, Y: pos.Y + arc.Y}
+				}
+
+				cur = append(cur, flattenArc(p0, arc, end, tolerance)...)
+				pos = end
+			}
+			havePrevC, havePrevQ = false, false
+		}
+	}
+
+	if len(cur) > 0 {
+		subpaths = append(subpaths, cur)
+	}
+
+	return subpaths
+}
+
+// isAbsCmdName reports whether a path command name (as stored on cmd.name,
+// e.g. "L" or "l") is the absolute variant of the command.
+func isAbsCmdName(name string) bool {
+	return name != "" && name[0] >= 'A' && name[0] <= 'Z'
+}
+
+// flattenCubic adaptively subdivides a cubic Bezier via de Casteljau's
+// algorithm, returning the flattened points from (but excluding) p0 through
+// p3.
+func flattenCubic(p0, p1, p2, p3 Point, tolerance float64) []Point {
+	if pointLineDistance(p1, p0, p3) <= tolerance && pointLineDistance(p2, p0, p3) <= tolerance {
+		return []Point{p3}
+	}
+
+	p01 := midpoint(p0, p1)
+	p12 := midpoint(p1, p2)
+	p23 := midpoint(p2, p3)
+	p012 := midpoint(p01, p12)
+	p123 := midpoint(p12, p23)
+	p0123 := midpoint(p012, p123)
+
+	left := flattenCubic(p0, p01, p012, p0123, tolerance)
+	right := flattenCubic(p0123, p123, p23, p3, tolerance)
+
+	return append(left, right...)
+}
+
+// flattenQuadratic elevates a quadratic Bezier to the equivalent cubic and
+// flattens that, returning the flattened points from (but excluding) p0
+// through p2.
+func flattenQuadratic(p0, p1, p2 Point, tolerance float64) []Point {
+	c1 := Point{X: p0.X + 2.0/3.0*(p1.X-p0.X), Y: p0.Y + 2.0/3.0*(p1.Y-p0.Y)}
+	c2 := Point{X: p2.X + 2.0/3.0*(p1.X-p2.X), Y: p2.Y + 2.0/3.0*(p1.Y-p2.Y)}
+
+	return flattenCubic(p0, c1, c2, p2, tolerance)
+}
+
+func midpoint(a, b Point) Point {
+	return Point{X: (a.X + b.X) / 2, Y: (a.Y + b.Y) / 2}
+}
+
+// pointLineDistance returns the perpendicular distance from p to the line
+// through a and b, or the distance from p to a if a and b coincide.
+func pointLineDistance(p, a, b Point) float64 {
+	dx := b.X - a.X
+	dy := b.Y - a.Y
+	lengthSq := dx*dx + dy*dy
+
+	if lengthSq == 0 {
+		return math.Hypot(p.X-a.X, p.Y-a.Y)
+	}
+
+	cross := dx*(a.Y-p.Y) - dy*(a.X-p.X)
+
+	return math.Abs(cross) / math.Sqrt(lengthSq)
+}
+
+// flattenArc converts the endpoint parameterization of an elliptical arc
+// from p0 to p1 into center parameterization (per the SVG spec appendix),
+// then subdivides it by angle so that the sagitta of each segment,
+// r(1 - cos(deltaTheta/2)), is at most tolerance. It returns the flattened
+// points from (but excluding) p0 through p1.
+func flattenArc(p0 Point, arc Arc, p1 Point, tolerance float64) []Point {
+	if p0 == p1 {
+		return nil
+	}
+
+	rx := math.Abs(arc.Rx)
+	ry := math.Abs(arc.Ry)
+	if rx == 0 || ry == 0 {
+		return []Point{p1}
+	}
+
+	phi := arc.XAxisRotation * math.Pi / 180
+	sinPhi, cosPhi := math.Sincos(phi)
+
+	dx2 := (p0.X - p1.X) / 2
+	dy2 := (p0.Y - p1.Y) / 2
+
+	x1p := cosPhi*dx2 + sinPhi*dy2
+	y1p := -sinPhi*dx2 + cosPhi*dy2
+
+	lambda := (x1p*x1p)/(rx*rx) + (y1p*y1p)/(ry*ry)
+	if lambda > 1 {
+		scale := math.Sqrt(lambda)
+		rx *= scale
+		ry *= scale
+	}
+
+	sign := -1.0
+	if arc.LargeArc != arc.Sweep {
+		sign = 1.0
+	}
+
+	num := rx*rx*ry*ry - rx*rx*y1p*y1p - ry*ry*x1p*x1p
+	den := rx*rx*y1p*y1p + ry*ry*x1p*x1p
+
+	co := 0.0
+	if den != 0 && num > 0 {
+		co = sign * math.Sqrt(num/den)
+	}
+
+	cxp := co * (rx * y1p / ry)
+	cyp := co * (-ry * x1p / rx)
+
+	cx := cosPhi*cxp - sinPhi*cyp + (p0.X+p1.X)/2
+	cy := sinPhi*cxp + cosPhi*cyp + (p0.Y+p1.Y)/2
+
+	theta1 := vectorAngle(1, 0, (x1p-cxp)/rx, (y1p-cyp)/ry)
+	deltaTheta := vectorAngle((x1p-cxp)/rx, (y1p-cyp)/ry, (-x1p-cxp)/rx, (-y1p-cyp)/ry)
+
+	if !arc.Sweep && deltaTheta > 0 {
+		deltaTheta -= 2 * math.Pi
+	} else if arc.Sweep && deltaTheta < 0 {
+		deltaTheta += 2 * math.Pi
+	}
+
+	maxR := math.Max(rx, ry)
+	step := 2 * math.Acos(clamp(1-tolerance/maxR, -1, 1))
+	if step <= 0 || math.IsNaN(step) {
+		step = math.Pi / 90
+	}
+
+	numSegments := int(math.Ceil(math.Abs(deltaTheta) / step))
+	if numSegments < 1 {
+		numSegments = 1
+	}
+
+	out := make([]Point, 0, numSegments)
+	for i := 1; i <= numSegments; i++ {
+		theta := theta1 + deltaTheta*float64(i)/float64(numSegments)
+		sinT, cosT := math.Sincos(theta)
+		out = append(out, Point{
+			X: cx + rx*cosT*cosPhi - ry*sinT*sinPhi,
+			Y: cy + rx*cosT*sinPhi + ry*sinT*cosPhi,
+		})
+	}
+	out[len(out)-1] = p1
+
+	return out
+}
+
+// vectorAngle returns the signed angle, in radians, from vector (ux,uy) to
+// vector (vx,vy).
+func vectorAngle(ux, uy, vx, vy float64) float64 {
+	dot := ux*vx + uy*vy
+	lenProd := math.Hypot(ux, uy) * math.Hypot(vx, vy)
+
+	cosAngle := clamp(dot/lenProd, -1, 1)
+	angle := math.Acos(cosAngle)
+
+	if ux*vy-uy*vx < 0 {
+		angle = -angle
+	}
+
+	return angle
+}
+
+func clamp(v, lo, hi float64) float64 {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+
+	return v
+}
@@ -0,0 +1,464 @@
+package gosvg
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PathParseError describes a failure to parse SVG path data, along with the
+// byte offset into the input at which the failure occurred.
+type PathParseError struct {
+	Offset int
+	Msg    string
+}
+
+func (e *PathParseError) Error() string {
+	return fmt.Sprintf("gosvg: parse path: %s (at byte offset %d)", e.Msg, e.Offset)
+}
+
+// ParsePath lexes an SVG path data string (the contents of a `d` attribute)
+// into a new Path.
+func ParsePath(d string) (*Path, error) {
+	p := &Path{}
+	if err := p.AppendD(d); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// AppendD lexes an SVG path data string and appends the resulting commands
+// to the path.
+func (p *Path) AppendD(d string) error {
+	l := &pathLexer{s: d}
+
+	for {
+		cmdCh, ok := l.peekCommand()
+		if !ok {
+			if l.atEnd() {
+				return nil
+			}
+
+			return l.errorf("expected a path command")
+		}
+		l.readByte()
+
+		if err := l.parseCommand(p, cmdCh); err != nil {
+			return err
+		}
+	}
+}
+
+func (l *pathLexer) parseCommand(p *Path, cmdCh byte) error {
+	switch cmdCh {
+	case 'M', 'm':
+		pts, err := l.readPoints()
+		if err != nil {
+			return err
+		}
+
+		p.addCmd(string(cmdCh), mCmd{pts: pts[:1]})
+
+		if len(pts) > 1 {
+			lc := byte('L')
+			if cmdCh == 'm' {
+				lc = 'l'
+			}
+			p.addCmd(string(lc), elCmd{pts: pts[1:], isAbs: lc == 'L'})
+		}
+	case 'Z', 'z':
+		p.addCmd(string(cmdCh), zCmd{})
+	case 'L', 'l':
+		pts, err := l.readPoints()
+		if err != nil {
+			return err
+		}
+
+		p.addCmd(string(cmdCh), elCmd{pts: pts, isAbs: cmdCh == 'L'})
+	case 'H', 'h':
+		xs, err := l.readNumbers()
+		if err != nil {
+			return err
+		}
+
+		p.addCmd(string(cmdCh), hCmd{xs: xs, isAbs: cmdCh == 'H'})
+	case 'V', 'v':
+		ys, err := l.readNumbers()
+		if err != nil {
+			return err
+		}
+
+		p.addCmd(string(cmdCh), vCmd{ys: ys, isAbs: cmdCh == 'V'})
+	case 'C', 'c':
+		cvs, err := l.readCCurves()
+		if err != nil {
+			return err
+		}
+
+		p.addCmd(string(cmdCh), cCmd{cvs: cvs, isAbs: cmdCh == 'C'})
+	case 'S', 's':
+		cvs, err := l.readSCurves()
+		if err != nil {
+			return err
+		}
+
+		p.addCmd(string(cmdCh), sCmd{cvs: cvs, isAbs: cmdCh == 'S'})
+	case 'Q', 'q':
+		cvs, err := l.readQCurves()
+		if err != nil {
+			return err
+		}
+
+		p.addCmd(string(cmdCh), qCmd{cvs: cvs, isAbs: cmdCh == 'Q'})
+	case 'T', 't':
+		pts, err := l.readPoints()
+		if err != nil {
+			return err
+		}
+
+		p.addCmd(string(cmdCh), tCmd{pts: pts, isAbs: cmdCh == 'T'})
+	case 'A', 'a':
+		arcs, err := l.readArcs()
+		if err != nil {
+			return err
+		}
+
+		p.addCmd(string(cmdCh), aCmd{arcs: arcs, isAbs: cmdCh == 'A'})
+	}
+
+	return nil
+}
+
+// pathLexer lexes SVG path data (the grammar behind the `d` attribute).
+type pathLexer struct {
+	s   string
+	pos int
+}
+
+const pathCommandLetters = "MmZzLlHhVvCcSsQqTtAa"
+
+func (l *pathLexer) errorf(format string, args ...interface{}) error {
+	return &PathParseError{Offset: l.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (l *pathLexer) skipSeparators() {
+	for l.pos < len(l.s) {
+		switch l.s[l.pos] {
+		case ' ', '\t', '\r', '\n', ',':
+			l.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (l *pathLexer) atEnd() bool {
+	l.skipSeparators()
+	return l.pos >= len(l.s)
+}
+
+// peekCommand skips leading separators and reports the next command letter,
+// without consuming it.
+func (l *pathLexer) peekCommand() (byte, bool) {
+	l.skipSeparators()
+	if l.pos >= len(l.s) {
+		return 0, false
+	}
+
+	c := l.s[l.pos]
+	if strings.IndexByte(pathCommandLetters, c) < 0 {
+		return 0, false
+	}
+
+	return c, true
+}
+
+func (l *pathLexer) readByte() {
+	l.pos++
+}
+
+// hasMoreNumber reports, without consuming input, whether more numeric data
+// follows for an implicit repeat of the current command.
+func (l *pathLexer) hasMoreNumber() bool {
+	save := l.pos
+	l.skipSeparators()
+	ok := l.pos < len(l.s) && isNumberStart(l.s[l.pos])
+	l.pos = save
+
+	return ok
+}
+
+func isNumberStart(c byte) bool {
+	return c == '+' || c == '-' || c == '.' || (c >= '0' && c <= '9')
+}
+
+func (l *pathLexer) readNumber() (float64, error) {
+	l.skipSeparators()
+	start := l.pos
+
+	if l.pos < len(l.s) && (l.s[l.pos] == '+' || l.s[l.pos] == '-') {
+		l.pos++
+	}
+
+	sawDigit := false
+	for l.pos < len(l.s) && l.s[l.pos] >= '0' && l.s[l.pos] <= '9' {
+		l.pos++
+		sawDigit = true
+	}
+
+	if l.pos < len(l.s) && l.s[l.pos] == '.' {
+		l.pos++
+		for l.pos < len(l.s) && l.s[l.pos] >= '0' && l.s[l.pos] <= '9' {
+			l.pos++
+			sawDigit = true
+		}
+	}
+
+	if !sawDigit {
+		return 0, l.errorf("expected a number")
+	}
+
+	if l.pos < len(l.s) && (l.s[l.pos] == 'e' || l.s[l.pos] == 'E') {
+		savedExp := l.pos
+		l.pos++
+
+		if l.pos < len(l.s) && (l.s[l.pos] == '+' || l.s[l.pos] == '-') {
+			l.pos++
+		}
+
+		expDigit := false
+		for l.pos < len(l.s) && l.s[l.pos] >= '0' && l.s[l.pos] <= '9' {
+			l.pos++
+			expDigit = true
+		}
+
+		if !expDigit {
+			l.pos = savedExp
+		}
+	}
+
+	numStr := l.s[start:l.pos]
+
+	v, err := strconv.ParseFloat(numStr, 64)
+	if err != nil {
+		return 0, l.errorf("invalid number %q", numStr)
+	}
+
+	return v, nil
+}
+
+// readFlag reads a single SVG arc flag: exactly one character, '0' or '1',
+// with no separator required before it.
+func (l *pathLexer) readFlag() (bool, error) {
+	l.skipSeparators()
+
+	if l.pos >= len(l.s) {
+		return false, l.errorf("expected a flag (0 or 1)")
+	}
+
+	c := l.s[l.pos]
+	if c != '0' && c != '1' {
+		return false, l.errorf("expected a flag (0 or 1), got %q", c)
+	}
+	l.pos++
+
+	return c == '1', nil
+}
+
+func (l *pathLexer) readNNumbers(n int) ([]float64, error) {
+	out := make([]float64, n)
+
+	for i := 0; i < n; i++ {
+		v, err := l.readNumber()
+		if err != nil {
+			return nil, err
+		}
+		out[i] = v
+	}
+
+	return out, nil
+}
+
+func (l *pathLexer) readPoint() (Point, error) {
+	nums, err := l.readNNumbers(2)
+	if err != nil {
+		return Point{}, err
+	}
+
+	return Point{X: nums[0], Y: nums[1]}, nil
+}
+
+func (l *pathLexer) readPoints() ([]Point, error) {
+	pt, err := l.readPoint()
+	if err != nil {
+		return nil, err
+	}
+
+	pts := []Point{pt}
+	for l.hasMoreNumber() {
+		pt, err := l.readPoint()
+		if err != nil {
+			return nil, err
+		}
+		pts = append(pts, pt)
+	}
+
+	return pts, nil
+}
+
+func (l *pathLexer) readNumbers() ([]float64, error) {
+	n, err := l.readNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	ns := []float64{n}
+	for l.hasMoreNumber() {
+		n, err := l.readNumber()
+		if err != nil {
+			return nil, err
+		}
+		ns = append(ns, n)
+	}
+
+	return ns, nil
+}
+
+func (l *pathLexer) readCCurve() (CCurve, error) {
+	nums, err := l.readNNumbers(6)
+	if err != nil {
+		return CCurve{}, err
+	}
+
+	return CCurve{X1: nums[0], Y1: nums[1], X2: nums[2], Y2: nums[3], X: nums[4], Y: nums[5]}, nil
+}
+
+func (l *pathLexer) readCCurves() ([]CCurve, error) {
+	cv, err := l.readCCurve()
+	if err != nil {
+		return nil, err
+	}
+
+	cvs := []CCurve{cv}
+	for l.hasMoreNumber() {
+		cv, err := l.readCCurve()
+		if err != nil {
+			return nil, err
+		}
+		cvs = append(cvs, cv)
+	}
+
+	return cvs, nil
+}
+
+func (l *pathLexer) readSCurve() (SCurve, error) {
+	nums, err := l.readNNumbers(4)
+	if err != nil {
+		return SCurve{}, err
+	}
+
+	return SCurve{X2: nums[0], Y2: nums[1], X: nums[2], Y: nums[3]}, nil
+}
+
+func (l *pathLexer) readSCurves() ([]SCurve, error) {
+	cv, err := l.readSCurve()
+	if err != nil {
+		return nil, err
+	}
+
+	cvs := []SCurve{cv}
+	for l.hasMoreNumber() {
+		cv, err := l.readSCurve()
+		if err != nil {
+			return nil, err
+		}
+		cvs = append(cvs, cv)
+	}
+
+	return cvs, nil
+}
+
+func (l *pathLexer) readQCurve() (QCurve, error) {
+	nums, err := l.readNNumbers(4)
+	if err != nil {
+		return QCurve{}, err
+	}
+
+	return QCurve{X1: nums[0], Y1: nums[1], X: nums[2], Y: nums[3]}, nil
+}
+
+func (l *pathLexer) readQCurves() ([]QCurve, error) {
+	cv, err := l.readQCurve()
+	if err != nil {
+		return nil, err
+	}
+
+	cvs := []QCurve{cv}
+	for l.hasMoreNumber() {
+		cv, err := l.readQCurve()
+		if err != nil {
+			return nil, err
+		}
+		cvs = append(cvs, cv)
+	}
+
+	return cvs, nil
+}
+
+func (l *pathLexer) readArc() (Arc, error) {
+	rx, err := l.readNumber()
+	if err != nil {
+		return Arc{}, err
+	}
+
+	ry, err := l.readNumber()
+	if err != nil {
+		return Arc{}, err
+	}
+
+	rot, err := l.readNumber()
+	if err != nil {
+		return Arc{}, err
+	}
+
+	large, err := l.readFlag()
+	if err != nil {
+		return Arc{}, err
+	}
+
+	sweep, err := l.readFlag()
+	if err != nil {
+		return Arc{}, err
+	}
+
+	x, err := l.readNumber()
+	if err != nil {
+		return Arc{}, err
+	}
+
+	y, err := l.readNumber()
+	if err != nil {
+		return Arc{}, err
+	}
+
+	return Arc{Rx: rx, Ry: ry, XAxisRotation: rot, LargeArc: large, Sweep: sweep, X: x, Y: y}, nil
+}
+
+func (l *pathLexer) readArcs() ([]Arc, error) {
+	a, err := l.readArc()
+	if err != nil {
+		return nil, err
+	}
+
+	arcs := []Arc{a}
+	for l.hasMoreNumber() {
+		a, err := l.readArc()
+		if err != nil {
+			return nil, err
+		}
+		arcs = append(arcs, a)
+	}
+
+	return arcs, nil
+}
@@ -52,28 +52,169 @@ func (m *valueMap) Unset(k string) {
 	delete(*m, k)
 }
 
-// Style represents the style attribute for any stylable SVG element.
+// paintKind distinguishes the kinds of value a Paint can hold.
+type paintKind int
+
+const (
+	paintColor paintKind = iota
+	paintNone
+	paintRef
+)
+
+// Paint represents an SVG paint value: a CSS color, "none", or a reference
+// to a gradient or pattern defined in a Defs.
+type Paint struct {
+	kind  paintKind
+	value string
+}
+
+// ColorPaint returns a Paint using the given CSS color, e.g. "red" or "#ff0000".
+func ColorPaint(color string) *Paint {
+	return &Paint{kind: paintColor, value: color}
+}
+
+// NonePaint returns a Paint that paints nothing ("none").
+func NonePaint() *Paint {
+	return &Paint{kind: paintNone}
+}
+
+// RefPaint returns a Paint that references the def with the given id
+// (typically a LinearGradient, RadialGradient, or Pattern), rendered as
+// url(#id).
+func RefPaint(id string) *Paint {
+	return &Paint{kind: paintRef, value: id}
+}
+
+func (p *Paint) attrString() string {
+	if p == nil {
+		return ""
+	}
+
+	switch p.kind {
+	case paintNone:
+		return "none"
+	case paintRef:
+		return fmt.Sprintf("url(#%s)", p.value)
+	default:
+		return p.value
+	}
+}
+
+// FillRule represents the fill-rule property, controlling how self-intersecting
+// paths and overlapping subpaths are filled.
+type FillRule int
+
+// The zero value of FillRule means the property is unset.
+const (
+	fillRuleUnset FillRule = iota
+	NonZero
+	EvenOdd
+)
+
+func (r FillRule) attrString() string {
+	switch r {
+	case NonZero:
+		return "nonzero"
+	case EvenOdd:
+		return "evenodd"
+	default:
+		return ""
+	}
+}
+
+// Style represents the style attribute for any stylable SVG element. The
+// typed fields cover the most common properties with type safety; anything
+// else can still be set through the embedded valueMap via Set/Get/Unset.
 type Style struct {
 	valueMap
+
+	Fill             *Paint
+	Stroke           *Paint
+	StrokeWidth      *float64
+	StrokeLineCap    string
+	StrokeLineJoin   string
+	StrokeDashArray  []float64
+	StrokeDashOffset *float64
+	Opacity          *float64
+	FillOpacity      *float64
+	StrokeOpacity    *float64
+	FillRule         FillRule
 }
 
 func (s Style) attrString() string {
-	if s.valueMap == nil {
-		return ""
+	var outs []string
+
+	if s.Fill != nil {
+		outs = append(outs, fmt.Sprintf("fill:%s", s.Fill.attrString()))
 	}
 
-	var outs []string
+	if s.Stroke != nil {
+		outs = append(outs, fmt.Sprintf("stroke:%s", s.Stroke.attrString()))
+	}
+
+	if s.StrokeWidth != nil {
+		outs = append(outs, fmt.Sprintf("stroke-width:%g", *s.StrokeWidth))
+	}
+
+	if s.StrokeLineCap != "" {
+		outs = append(outs, fmt.Sprintf("stroke-linecap:%s", s.StrokeLineCap))
+	}
+
+	if s.StrokeLineJoin != "" {
+		outs = append(outs, fmt.Sprintf("stroke-linejoin:%s", s.StrokeLineJoin))
+	}
+
+	if len(s.StrokeDashArray) > 0 {
+		var dashes []string
+		for _, d := range s.StrokeDashArray {
+			dashes = append(dashes, fmt.Sprintf("%g", d))
+		}
+		outs = append(outs, fmt.Sprintf("stroke-dasharray:%s", strings.Join(dashes, ",")))
+	}
+
+	if s.StrokeDashOffset != nil {
+		outs = append(outs, fmt.Sprintf("stroke-dashoffset:%g", *s.StrokeDashOffset))
+	}
+
+	if s.Opacity != nil {
+		outs = append(outs, fmt.Sprintf("opacity:%g", *s.Opacity))
+	}
+
+	if s.FillOpacity != nil {
+		outs = append(outs, fmt.Sprintf("fill-opacity:%g", *s.FillOpacity))
+	}
+
+	if s.StrokeOpacity != nil {
+		outs = append(outs, fmt.Sprintf("stroke-opacity:%g", *s.StrokeOpacity))
+	}
+
+	if fillRule := s.FillRule.attrString(); fillRule != "" {
+		outs = append(outs, fmt.Sprintf("fill-rule:%s", fillRule))
+	}
 
 	for k, v := range s.valueMap {
-		valStr := fmt.Sprintf("%s:%s", k, v)
-		outs = append(outs, valStr)
+		outs = append(outs, fmt.Sprintf("%s:%s", k, v))
+	}
+
+	if len(outs) == 0 {
+		return ""
 	}
 
 	out := strings.Join(outs, ";")
 
-	out = fmt.Sprintf("style=\"%s\"", out)
+	return fmt.Sprintf("style=\"%s\"", out)
+}
 
-	return out
+// SetFillRef sets the fill property to reference the def with the given id,
+// e.g. a LinearGradient, RadialGradient, or Pattern from a Defs.
+func (s *Style) SetFillRef(id string) {
+	s.Fill = RefPaint(id)
+}
+
+// SetStrokeRef sets the stroke property to reference the def with the given
+// id, e.g. a LinearGradient, RadialGradient, or Pattern from a Defs.
+func (s *Style) SetStrokeRef(id string) {
+	s.Stroke = RefPaint(id)
 }
 
 // Transform represents a series of transforms applied to an SVG element.
@@ -154,7 +295,7 @@ func (v ViewBox) attrString() string {
 		return ""
 	}
 
-	out := fmt.Sprintf("%g %g %g %g", v.minX, v.minX, v.width, v.height)
+	out := fmt.Sprintf("%g %g %g %g", v.minX, v.minY, v.width, v.height)
 
 	out = fmt.Sprintf("viewBox=\"%s\"", out)
 
@@ -253,6 +394,14 @@ func (c *container) Rect(x, y, w, h float64) *Rect {
 	return r
 }
 
+// RoundRect generates a new rect with rounded corners in the given container.
+func (c *container) RoundRect(x, y, w, h, rx, ry float64) *Rect {
+	r := &Rect{X: x, Y: y, Width: w, Height: h, Rx: rx, Ry: ry}
+	c.contents = append(c.contents, r)
+
+	return r
+}
+
 // Polygon generates a new polygon in the given container.
 func (c *container) Polygon(pts ...Point) *Polygon {
 	p := &Polygon{Points: pts}
@@ -423,6 +572,8 @@ type Rect struct {
 	Height float64
 	X      float64
 	Y      float64
+	Rx     float64
+	Ry     float64
 }
 
 func (r *Rect) attrStrings() []string {
@@ -431,7 +582,17 @@ func (r *Rect) attrStrings() []string {
 	x := floatAttr("x", r.X)
 	y := floatAttr("y", r.Y)
 
-	return append(r.ShapeAttrs.attrStrings(), w, h, x, y)
+	attrs := append(r.ShapeAttrs.attrStrings(), w, h, x, y)
+
+	if r.Rx != 0 {
+		attrs = append(attrs, floatAttr("rx", r.Rx))
+	}
+
+	if r.Ry != 0 {
+		attrs = append(attrs, floatAttr("ry", r.Ry))
+	}
+
+	return attrs
 }
 
 func (r *Rect) render(w io.Writer) error {
@@ -726,6 +887,47 @@ func (c tCmd) strings() []string {
 	return out
 }
 
+// Arc represents an elliptical arc curve.
+type Arc struct {
+	Rx            float64
+	Ry            float64
+	XAxisRotation float64
+	LargeArc      bool
+	Sweep         bool
+	X             float64
+	Y             float64
+}
+
+func flagStr(b bool) string {
+	if b {
+		return "1"
+	}
+
+	return "0"
+}
+
+type aCmd struct {
+	arcs  []Arc
+	isAbs bool
+}
+
+func (a aCmd) strings() []string {
+	var out []string
+
+	for _, arc := range a.arcs {
+		out = append(out,
+			fmt.Sprintf("%g", arc.Rx),
+			fmt.Sprintf("%g", arc.Ry),
+			fmt.Sprintf("%g", arc.XAxisRotation),
+			flagStr(arc.LargeArc),
+			flagStr(arc.Sweep),
+			fmt.Sprintf("%g", arc.X),
+			fmt.Sprintf("%g", arc.Y))
+	}
+
+	return out
+}
+
 // Path represents a path through a given coordinate system (the path element).
 type Path struct {
 	ShapeAttrs
@@ -824,6 +1026,16 @@ func (p *Path) Tr(cvs ...Point) *Path {
 	return p.addCmd("t", tCmd{pts: cvs})
 }
 
+// Aa appends an absolute elliptical arc command to the path.
+func (p *Path) Aa(arcs ...Arc) *Path {
+	return p.addCmd("A", aCmd{arcs: arcs, isAbs: true})
+}
+
+// Ar appends a relative elliptical arc command to the path.
+func (p *Path) Ar(arcs ...Arc) *Path {
+	return p.addCmd("a", aCmd{arcs: arcs})
+}
+
 func (p *Path) pathStr() string {
 	var outs []string
 	accumLen := 0
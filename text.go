@@ -0,0 +1,205 @@
+package gosvg
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+var xmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\"", "&quot;")
+
+// writeEscaped writes s to w with the XML-significant characters
+// ("&", "<", ">", "\"") escaped.
+func writeEscaped(w io.Writer, s string) error {
+	_, err := w.Write([]byte(xmlEscaper.Replace(s)))
+
+	return err
+}
+
+func floatListAttr(name string, vals []float64) string {
+	if len(vals) == 0 {
+		return ""
+	}
+
+	var strs []string
+	for _, v := range vals {
+		strs = append(strs, fmt.Sprintf("%g", v))
+	}
+
+	return stringAttr(name, strings.Join(strs, " "))
+}
+
+// SetFontFamily sets the font-family property.
+func (s *Style) SetFontFamily(family string) {
+	s.Set("font-family", family)
+}
+
+// SetFontSize sets the font-size property.
+func (s *Style) SetFontSize(size string) {
+	s.Set("font-size", size)
+}
+
+// SetTextAnchor sets the text-anchor property.
+func (s *Style) SetTextAnchor(anchor string) {
+	s.Set("text-anchor", anchor)
+}
+
+// SetDominantBaseline sets the dominant-baseline property.
+func (s *Style) SetDominantBaseline(baseline string) {
+	s.Set("dominant-baseline", baseline)
+}
+
+// Text represents a run of text (the text element).
+type Text struct {
+	ShapeAttrs
+	container
+	X            []float64
+	Y            []float64
+	Dx           []float64
+	Dy           []float64
+	Rotate       []float64
+	TextLength   *float64
+	LengthAdjust string
+	Content      string
+}
+
+// Text generates a new text element in the given container at (x, y).
+func (c *container) Text(x, y float64, content string) *Text {
+	t := &Text{
+		X:         []float64{x},
+		Y:         []float64{y},
+		Content:   content,
+		container: container{name: "text"},
+	}
+	c.contents = append(c.contents, t)
+
+	return t
+}
+
+func (t *Text) attrStrings() []string {
+	attrs := t.ShapeAttrs.attrStrings()
+
+	attrs = append(attrs,
+		floatListAttr("x", t.X),
+		floatListAttr("y", t.Y),
+		floatListAttr("dx", t.Dx),
+		floatListAttr("dy", t.Dy),
+		floatListAttr("rotate", t.Rotate))
+
+	if t.TextLength != nil {
+		attrs = append(attrs, floatAttr("textLength", *t.TextLength))
+	}
+
+	if t.LengthAdjust != "" {
+		attrs = append(attrs, stringAttr("lengthAdjust", t.LengthAdjust))
+	}
+
+	return attrs
+}
+
+func (t *Text) render(w io.Writer) error {
+	attrString := strings.Join(t.attrStrings(), " ")
+
+	opening := fmt.Sprintf("<text %s>", attrString)
+	if _, err := w.Write([]byte(opening)); err != nil {
+		return err
+	}
+
+	if err := writeEscaped(w, t.Content); err != nil {
+		return err
+	}
+
+	for _, r := range t.contents {
+		if err := r.render(w); err != nil {
+			return err
+		}
+	}
+
+	_, err := w.Write([]byte("</text>"))
+
+	return err
+}
+
+// TSpan represents an inline styled run of text within a Text (the tspan element).
+type TSpan struct {
+	ShapeAttrs
+	X       []float64
+	Y       []float64
+	Dx      []float64
+	Dy      []float64
+	Rotate  []float64
+	Content string
+}
+
+// TSpan appends an inline styled run of text to the text element.
+func (t *Text) TSpan(content string) *TSpan {
+	s := &TSpan{Content: content}
+	t.contents = append(t.contents, s)
+
+	return s
+}
+
+func (s *TSpan) attrStrings() []string {
+	attrs := s.ShapeAttrs.attrStrings()
+
+	return append(attrs,
+		floatListAttr("x", s.X),
+		floatListAttr("y", s.Y),
+		floatListAttr("dx", s.Dx),
+		floatListAttr("dy", s.Dy),
+		floatListAttr("rotate", s.Rotate))
+}
+
+func (s *TSpan) render(w io.Writer) error {
+	attrString := strings.Join(s.attrStrings(), " ")
+
+	opening := fmt.Sprintf("<tspan %s>", attrString)
+	if _, err := w.Write([]byte(opening)); err != nil {
+		return err
+	}
+
+	if err := writeEscaped(w, s.Content); err != nil {
+		return err
+	}
+
+	_, err := w.Write([]byte("</tspan>"))
+
+	return err
+}
+
+// TextPath represents text flowed along a referenced Path (the textPath element).
+type TextPath struct {
+	ShapeAttrs
+	Href    string
+	Content string
+}
+
+// TextPath appends a run of text flowed along the path referenced by href
+// (typically "#id" of a Path defined elsewhere) to the text element.
+func (t *Text) TextPath(href string, content string) *TextPath {
+	tp := &TextPath{Href: href, Content: content}
+	t.contents = append(t.contents, tp)
+
+	return tp
+}
+
+func (tp *TextPath) attrStrings() []string {
+	return append(tp.ShapeAttrs.attrStrings(), stringAttr("xlink:href", tp.Href))
+}
+
+func (tp *TextPath) render(w io.Writer) error {
+	attrString := strings.Join(tp.attrStrings(), " ")
+
+	opening := fmt.Sprintf("<textPath %s>", attrString)
+	if _, err := w.Write([]byte(opening)); err != nil {
+		return err
+	}
+
+	if err := writeEscaped(w, tp.Content); err != nil {
+		return err
+	}
+
+	_, err := w.Write([]byte("</textPath>"))
+
+	return err
+}